@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/micro/cli"
+	"github.com/micro/go-micro"
+	"github.com/micro/micro/runtime/installer"
+	rtsource "github.com/micro/micro/runtime/source"
+)
+
+// InstallUsage message for the micro install command
+const InstallUsage = "Required usage: micro install service --name example --source go/package/import/path (optional: --user|--system)"
+
+// UninstallUsage message for the micro uninstall command
+const UninstallUsage = "Required usage: micro uninstall service --name example"
+
+func installService(ctx *cli.Context, srvOpts ...micro.Option) {
+	if len(ctx.Args()) == 0 || ctx.Args()[0] != "service" {
+		fmt.Println(InstallUsage)
+		return
+	}
+
+	name := ctx.String("name")
+	source := ctx.String("source")
+	env := ctx.StringSlice("env")
+
+	if len(name) == 0 || len(source) == 0 {
+		fmt.Println(InstallUsage)
+		return
+	}
+
+	scope := installer.User
+	if ctx.Bool("system") {
+		scope = installer.System
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// resolve the working directory the same way `micro run` does, so
+	// the installed unit/plist starts the service from the right place
+	dir, _, err := rtsource.Resolve(source)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if dir == "." {
+		if dir, err = os.Getwd(); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	service := installer.Service{
+		Name:  name,
+		Exec:  []string{exe, "run", "service", "--name", name, "--source", source},
+		Dir:   dir,
+		Env:   append(defaultEnv(), env...),
+		Scope: scope,
+	}
+
+	if err := installer.New().Install(service); err != nil {
+		fmt.Println(err)
+		return
+	}
+}
+
+func uninstallService(ctx *cli.Context, srvOpts ...micro.Option) {
+	if len(ctx.Args()) == 0 || ctx.Args()[0] != "service" {
+		fmt.Println(UninstallUsage)
+		return
+	}
+
+	name := ctx.String("name")
+	if len(name) == 0 {
+		fmt.Println(UninstallUsage)
+		return
+	}
+
+	scope := installer.User
+	if ctx.Bool("system") {
+		scope = installer.System
+	}
+
+	if err := installer.New().Uninstall(name, scope); err != nil {
+		fmt.Println(err)
+		return
+	}
+}
@@ -0,0 +1,140 @@
+// +build darwin
+
+package installer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"text/template"
+)
+
+const plistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+	{{range .Exec}}	<string>{{.}}</string>
+	{{end}}</array>
+	<key>WorkingDirectory</key>
+	<string>{{.Dir}}</string>
+	<key>EnvironmentVariables</key>
+	<dict>
+	{{range .Env}}	<key>{{.Key}}</key>
+		<string>{{.Value}}</string>
+	{{end}}</dict>
+	<key>KeepAlive</key>
+	<true/>
+	<key>RunAtLoad</key>
+	<true/>
+</dict>
+</plist>
+`
+
+type darwinInstaller struct{}
+
+// New returns the launchd-backed Installer for macOS.
+func New() Installer {
+	return &darwinInstaller{}
+}
+
+func (i *darwinInstaller) Install(service Service) error {
+	path, err := plistPath(service.Name, service.Scope)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tmpl, err := template.New("plist").Parse(plistTemplate)
+	if err != nil {
+		return err
+	}
+
+	execArgs := make([]string, len(service.Exec))
+	for i, a := range service.Exec {
+		execArgs[i] = escapeXML(a)
+	}
+
+	data := struct {
+		Label string
+		Exec  []string
+		Dir   string
+		Env   []struct{ Key, Value string }
+	}{
+		Label: escapeXML(fmt.Sprintf("io.micro.%s", service.Name)),
+		Exec:  execArgs,
+		Dir:   escapeXML(service.Dir),
+	}
+	for _, e := range service.Env {
+		key, value := splitEnv(e)
+		data.Env = append(data.Env, struct{ Key, Value string }{escapeXML(key), escapeXML(value)})
+	}
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return err
+	}
+
+	return exec.Command("launchctl", "load", "-w", path).Run()
+}
+
+func (i *darwinInstaller) Uninstall(name string, scope Scope) error {
+	path, err := plistPath(name, scope)
+	if err != nil {
+		return err
+	}
+
+	if err := exec.Command("launchctl", "unload", path).Run(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+func plistPath(name string, scope Scope) (string, error) {
+	label := fmt.Sprintf("io.micro.%s.plist", name)
+
+	if scope == System {
+		return filepath.Join("/Library/LaunchDaemons", label), nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(u.HomeDir, "Library/LaunchAgents", label), nil
+}
+
+// escapeXML escapes a value for safe inclusion as plist character
+// data, so an exec arg, path or env value containing &, < or > can't
+// produce a malformed or mis-parsed plist.
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func splitEnv(kv string) (string, string) {
+	for i := 0; i < len(kv); i++ {
+		if kv[i] == '=' {
+			return kv[:i], kv[i+1:]
+		}
+	}
+	return kv, ""
+}
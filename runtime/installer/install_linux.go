@@ -0,0 +1,128 @@
+// +build linux
+
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const unitTemplate = `[Unit]
+Description=micro service {{.Name}}
+After=network.target
+
+[Service]
+ExecStart={{.Exec}}
+WorkingDirectory={{.Dir}}
+Restart=on-failure
+{{range .Env}}Environment={{.}}
+{{end}}
+[Install]
+WantedBy={{if .System}}multi-user.target{{else}}default.target{{end}}
+`
+
+type linuxInstaller struct{}
+
+// New returns the systemd-backed Installer for Linux.
+func New() Installer {
+	return &linuxInstaller{}
+}
+
+func (i *linuxInstaller) Install(service Service) error {
+	path, err := unitPath(service.Name, service.Scope)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tmpl, err := template.New("unit").Parse(unitTemplate)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Name   string
+		Exec   string
+		Dir    string
+		Env    []string
+		System bool
+	}{
+		Name:   service.Name,
+		Exec:   strings.Join(service.Exec, " "),
+		Dir:    service.Dir,
+		Env:    service.Env,
+		System: service.Scope == System,
+	}
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return err
+	}
+
+	if err := systemctl(service.Scope, "daemon-reload"); err != nil {
+		return err
+	}
+
+	unit := unitName(service.Name)
+	if err := systemctl(service.Scope, "enable", unit); err != nil {
+		return err
+	}
+
+	return systemctl(service.Scope, "start", unit)
+}
+
+func (i *linuxInstaller) Uninstall(name string, scope Scope) error {
+	unit := unitName(name)
+
+	systemctl(scope, "stop", unit)
+	systemctl(scope, "disable", unit)
+
+	path, err := unitPath(name, scope)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+func systemctl(scope Scope, args ...string) error {
+	cmd := []string{"systemctl"}
+	if scope == User {
+		cmd = append(cmd, "--user")
+	}
+	cmd = append(cmd, args...)
+
+	return exec.Command(cmd[0], cmd[1:]...).Run()
+}
+
+func unitName(name string) string {
+	return fmt.Sprintf("micro-%s.service", name)
+}
+
+func unitPath(name string, scope Scope) (string, error) {
+	unit := unitName(name)
+
+	if scope == System {
+		return filepath.Join("/etc/systemd/system", unit), nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(u.HomeDir, ".config/systemd/user", unit), nil
+}
@@ -0,0 +1,117 @@
+// +build windows
+
+package installer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// recoveryResetPeriod is how long the service must run without failing
+// before the SCM resets the failure count it uses to pick the next
+// recovery action.
+const recoveryResetPeriod = 24 * time.Hour
+
+type windowsInstaller struct{}
+
+// New returns the Windows Service Manager backed Installer.
+func New() Installer {
+	return &windowsInstaller{}
+}
+
+func (i *windowsInstaller) Install(service Service) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	name := serviceName(service.Name)
+	exe, args := commandFor(service)
+
+	s, err := m.CreateService(name, exe, mgr.Config{
+		DisplayName: name,
+		StartType:   mgr.StartAutomatic,
+	}, args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// mgr.Config has no environment field, so MICRO_* config and any
+	// --env vars are written to the service's own registry key, same
+	// place the Service Control Manager reads "Environment" from
+	if len(service.Env) > 0 {
+		if err := setEnvironment(name, service.Env); err != nil {
+			return err
+		}
+	}
+
+	// mirror darwin's KeepAlive / linux's Restart=on-failure: have the
+	// SCM itself restart the process on crashes
+	actions := []mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	}
+	if err := s.SetRecoveryActions(actions, uint32(recoveryResetPeriod.Seconds())); err != nil {
+		return err
+	}
+
+	return s.Start()
+}
+
+// commandFor returns the exe/args CreateService should launch.
+// mgr.Config has no working-directory field, so when one is needed we
+// route the launch through cmd.exe to cd into it first.
+func commandFor(service Service) (string, []string) {
+	exe, args := service.Exec[0], service.Exec[1:]
+	if len(service.Dir) == 0 {
+		return exe, args
+	}
+
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, fmt.Sprintf("%q", exe))
+	for _, a := range args {
+		quoted = append(quoted, fmt.Sprintf("%q", a))
+	}
+
+	script := fmt.Sprintf("cd /d %q && %s", service.Dir, strings.Join(quoted, " "))
+	return "cmd.exe", []string{"/c", script}
+}
+
+func setEnvironment(name string, env []string) error {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\`+name, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+
+	return key.SetStringsValue("Environment", env)
+}
+
+func (i *windowsInstaller) Uninstall(name string, scope Scope) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName(name))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	s.Control(svc.Stop)
+	return s.Delete()
+}
+
+func serviceName(name string) string {
+	return "micro-" + strings.Replace(name, " ", "-", -1)
+}
@@ -0,0 +1,42 @@
+// Package installer persists micro-managed services to the host's
+// native init system so they survive reboots without a runtime or
+// Kubernetes deployment.
+package installer
+
+// Scope controls where a service definition is installed.
+type Scope string
+
+const (
+	// User installs the service for the current user only
+	// (LaunchAgents, systemd --user, etc).
+	User Scope = "user"
+	// System installs the service for all users, typically requiring
+	// elevated privileges (LaunchDaemons, system systemd, Windows
+	// services).
+	System Scope = "system"
+)
+
+// Service describes a process to be installed into the host init
+// system.
+type Service struct {
+	// Name uniquely identifies the installed service.
+	Name string
+	// Exec is the full command vector to run, e.g. ["/usr/local/bin/micro", "run", "..."].
+	Exec []string
+	// Dir is the working directory the process should be started in.
+	Dir string
+	// Env is the set of environment variables passed to the process.
+	Env []string
+	// Scope selects a user or system level install.
+	Scope Scope
+}
+
+// Installer persists and removes Service definitions from the host's
+// native init system.
+type Installer interface {
+	// Install writes the service definition and enables/starts it.
+	Install(service Service) error
+	// Uninstall stops, disables and removes a previously installed
+	// service.
+	Uninstall(name string, scope Scope) error
+}
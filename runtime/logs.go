@@ -0,0 +1,140 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/micro/cli"
+	"github.com/micro/go-micro"
+	"github.com/micro/go-micro/config/cmd"
+	"github.com/micro/go-micro/runtime"
+	rs "github.com/micro/go-micro/runtime/service"
+)
+
+// LogsUsage message for the micro logs command
+const LogsUsage = "Required usage: micro logs service --name example (optional: --version latest, --follow, --since 5m, --count 100, --json)"
+
+// LogRecord is a single line of output captured from a running service.
+type LogRecord struct {
+	Service string `json:"service"`
+	Message string `json:"message"`
+}
+
+// LogsOptions configure a Logs call.
+type LogsOptions struct {
+	Count  int
+	Since  string
+	Stream bool
+}
+
+// LogsOption sets a LogsOptions field.
+type LogsOption func(*LogsOptions)
+
+// LogsCount limits the number of historical log lines returned for
+// each instance.
+func LogsCount(n int) LogsOption {
+	return func(o *LogsOptions) { o.Count = n }
+}
+
+// LogsSince carries a duration, e.g. "5m", for restricting returned log
+// lines to those emitted within it. localRuntime does not record when
+// a line was emitted and ignores it.
+func LogsSince(d string) LogsOption {
+	return func(o *LogsOptions) { o.Since = d }
+}
+
+// LogsStream toggles whether the call keeps streaming new lines as
+// they're produced, rather than returning once historical output is
+// exhausted.
+func LogsStream(stream bool) LogsOption {
+	return func(o *LogsOptions) { o.Stream = stream }
+}
+
+// LogStream is a handle on an in-progress Logs call.
+type LogStream interface {
+	Chan() <-chan LogRecord
+	Stop() error
+}
+
+// logsRuntime is implemented by runtime.Runtime backends that support
+// streaming logs. We assert against this locally, rather than adding
+// Logs to go-micro's runtime.Runtime directly, since not every
+// runtime implementation in this series carries it yet.
+type logsRuntime interface {
+	Logs(service *runtime.Service, opts ...LogsOption) (LogStream, error)
+}
+
+func logsService(ctx *cli.Context, srvOpts ...micro.Option) {
+	// we expect `micro logs service`
+	if len(ctx.Args()) == 0 || ctx.Args()[0] != "service" {
+		fmt.Println(LogsUsage)
+		return
+	}
+
+	// get the args
+	name := ctx.String("name")
+	version := ctx.String("version")
+	local := ctx.Bool("local")
+	follow := ctx.Bool("follow")
+	since := ctx.String("since")
+	count := ctx.Int("count")
+	jsonFormat := ctx.Bool("json")
+
+	if len(name) == 0 {
+		fmt.Println(LogsUsage)
+		return
+	}
+
+	var r runtime.Runtime
+	switch local {
+	case true:
+		// capture happens in the same process as `micro run --local`;
+		// wrapping here lets this command serve logs when invoked as
+		// part of that same process (e.g. future in-process tooling),
+		// though a separate `micro logs` invocation has its own empty
+		// capture registry and will report nothing captured
+		r = &localRuntime{Runtime: *cmd.DefaultCmd.Options().Runtime}
+	default:
+		r = rs.NewRuntime()
+	}
+
+	lr, ok := r.(logsRuntime)
+	if !ok {
+		fmt.Printf("%T does not support log streaming yet\n", r)
+		return
+	}
+
+	service := &runtime.Service{
+		Name:    name,
+		Version: version,
+	}
+
+	opts := []LogsOption{
+		LogsCount(count),
+	}
+	if len(since) > 0 {
+		opts = append(opts, LogsSince(since))
+	}
+	if follow {
+		opts = append(opts, LogsStream(true))
+	}
+
+	stream, err := lr.Logs(service, opts...)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Stop()
+
+	// record.Service is already NAME/VERSION (or just NAME), so multiple
+	// instances multiplexed onto the same stream stay distinguishable
+	for record := range stream.Chan() {
+		if jsonFormat {
+			enc := json.NewEncoder(os.Stdout)
+			enc.Encode(record)
+			continue
+		}
+		fmt.Printf("%s: %s\n", record.Service, record.Message)
+	}
+}
@@ -0,0 +1,218 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/micro/go-micro/runtime"
+)
+
+// logBacklog bounds how many lines of history a newly attached
+// `micro logs` call can replay per instance.
+const logBacklog = 100
+
+// instanceLog buffers the recent output of one running service
+// instance (one name+version) and fans new lines out to subscribers,
+// so multiple `micro logs` calls can tail the same instance and late
+// attachers still see recent history.
+type instanceLog struct {
+	mu    sync.Mutex
+	label string
+	lines []string
+	subs  map[chan LogRecord]struct{}
+}
+
+func newInstanceLog(label string) *instanceLog {
+	return &instanceLog{label: label, subs: make(map[chan LogRecord]struct{})}
+}
+
+func (l *instanceLog) write(line string) {
+	record := LogRecord{Service: l.label, Message: line}
+
+	l.mu.Lock()
+	l.lines = append(l.lines, line)
+	if len(l.lines) > logBacklog {
+		l.lines = l.lines[len(l.lines)-logBacklog:]
+	}
+	subs := make([]chan LogRecord, 0, len(l.subs))
+	for ch := range l.subs {
+		subs = append(subs, ch)
+	}
+	l.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- record:
+		default:
+			// slow subscriber, drop rather than block the service's output
+		}
+	}
+}
+
+func (l *instanceLog) snapshot(count int) []LogRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lines := l.lines
+	if count > 0 && count < len(lines) {
+		lines = lines[len(lines)-count:]
+	}
+
+	records := make([]LogRecord, len(lines))
+	for i, line := range lines {
+		records[i] = LogRecord{Service: l.label, Message: line}
+	}
+	return records
+}
+
+func (l *instanceLog) subscribe() chan LogRecord {
+	ch := make(chan LogRecord, 64)
+	l.mu.Lock()
+	l.subs[ch] = struct{}{}
+	l.mu.Unlock()
+	return ch
+}
+
+func (l *instanceLog) unsubscribe(ch chan LogRecord) {
+	l.mu.Lock()
+	delete(l.subs, ch)
+	l.mu.Unlock()
+	close(ch)
+}
+
+// teeLines copies writes through to out unchanged, while also
+// splitting them on newlines and feeding each complete line to log.
+type teeLines struct {
+	out io.Writer
+	log *instanceLog
+	buf []byte
+}
+
+func (t *teeLines) Write(p []byte) (int, error) {
+	n, err := t.out.Write(p)
+
+	t.buf = append(t.buf, p...)
+	for {
+		i := bytes.IndexByte(t.buf, '\n')
+		if i < 0 {
+			break
+		}
+		t.log.write(string(t.buf[:i]))
+		t.buf = t.buf[i+1:]
+	}
+
+	return n, err
+}
+
+var (
+	logsMu     sync.Mutex
+	logsByName = map[string]map[string]*instanceLog{}
+)
+
+// captureOutput wraps out so the instance's stdout/stderr keeps
+// flowing to the console as before, while also recording it under
+// name/version for logsRuntime to serve back via `micro logs`.
+func captureOutput(out io.Writer, name, version string) io.Writer {
+	label := name
+	if len(version) > 0 {
+		label = fmt.Sprintf("%s/%s", name, version)
+	}
+
+	logsMu.Lock()
+	versions, ok := logsByName[name]
+	if !ok {
+		versions = make(map[string]*instanceLog)
+		logsByName[name] = versions
+	}
+	l := newInstanceLog(label)
+	versions[version] = l
+	logsMu.Unlock()
+
+	return &teeLines{out: out, log: l}
+}
+
+// localRuntime decorates a runtime.Runtime with the ability to stream
+// the output captureOutput recorded for services it started, since
+// go-micro's local runtime has no Logs support of its own.
+type localRuntime struct {
+	runtime.Runtime
+}
+
+func (l *localRuntime) Logs(service *runtime.Service, opts ...LogsOption) (LogStream, error) {
+	var options LogsOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	logsMu.Lock()
+	versions, ok := logsByName[service.Name]
+	if !ok {
+		logsMu.Unlock()
+		return nil, fmt.Errorf("no captured output for %s", service.Name)
+	}
+
+	var targets []*instanceLog
+	if len(service.Version) > 0 {
+		instance, ok := versions[service.Version]
+		if !ok {
+			logsMu.Unlock()
+			return nil, fmt.Errorf("no captured output for %s/%s", service.Name, service.Version)
+		}
+		targets = []*instanceLog{instance}
+	} else {
+		// no version pinned: multiplex every instance running under
+		// this name
+		for _, instance := range versions {
+			targets = append(targets, instance)
+		}
+	}
+	logsMu.Unlock()
+
+	return newLogStream(targets, options), nil
+}
+
+// logStream fans multiple instanceLog subscriptions into a single
+// channel.
+type logStream struct {
+	ch     chan LogRecord
+	unsubs []func()
+}
+
+func (s *logStream) Chan() <-chan LogRecord { return s.ch }
+
+func (s *logStream) Stop() error {
+	for _, unsub := range s.unsubs {
+		unsub()
+	}
+	return nil
+}
+
+func newLogStream(targets []*instanceLog, options LogsOptions) *logStream {
+	stream := &logStream{ch: make(chan LogRecord, 256)}
+
+	for _, instance := range targets {
+		for _, record := range instance.snapshot(options.Count) {
+			stream.ch <- record
+		}
+	}
+
+	if !options.Stream {
+		close(stream.ch)
+		return stream
+	}
+
+	for _, instance := range targets {
+		sub := instance.subscribe()
+		stream.unsubs = append(stream.unsubs, func() { instance.unsubscribe(sub) })
+
+		go func(sub chan LogRecord) {
+			for record := range sub {
+				stream.ch <- record
+			}
+		}(sub)
+	}
+
+	return stream
+}
@@ -0,0 +1,44 @@
+package runtime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/micro/go-micro/runtime"
+)
+
+func TestCaptureOutputAndLogsSnapshot(t *testing.T) {
+	var out bytes.Buffer
+
+	w := captureOutput(&out, "test-capture-svc", "v1")
+	if _, err := w.Write([]byte("hello\nworld\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := out.String(); got != "hello\nworld\n" {
+		t.Errorf("captureOutput did not pass writes through unchanged: got %q", got)
+	}
+
+	lr := &localRuntime{}
+	stream, err := lr.Logs(&runtime.Service{Name: "test-capture-svc", Version: "v1"}, LogsCount(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Stop()
+
+	var lines []string
+	for record := range stream.Chan() {
+		lines = append(lines, record.Message)
+	}
+
+	if len(lines) != 2 || lines[0] != "hello" || lines[1] != "world" {
+		t.Errorf("unexpected snapshot from Logs: %v", lines)
+	}
+}
+
+func TestLocalRuntimeLogsUnknownService(t *testing.T) {
+	lr := &localRuntime{}
+	if _, err := lr.Logs(&runtime.Service{Name: "never-captured"}); err == nil {
+		t.Error("expected an error for a service with no captured output")
+	}
+}
@@ -3,13 +3,16 @@ package runtime
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/micro/cli"
 	"github.com/micro/go-micro"
@@ -17,6 +20,9 @@ import (
 	"github.com/micro/go-micro/runtime"
 	rs "github.com/micro/go-micro/runtime/service"
 	"github.com/micro/micro/runtime/notifier"
+	rtsource "github.com/micro/micro/runtime/source"
+	"github.com/micro/micro/runtime/supervisor"
+	"github.com/micro/micro/runtime/watcher"
 )
 
 const (
@@ -80,7 +86,9 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	// local usage specified
 	switch local {
 	case true:
-		r = *cmd.DefaultCmd.Options().Runtime
+		// wrap the local runtime so it can serve captured output back
+		// to `micro logs`, which go-micro's local runtime can't do itself
+		r = &localRuntime{Runtime: *cmd.DefaultCmd.Options().Runtime}
 		// NOTE: When in local mode, we consider source to be
 		// the filesystem path to the source of the service
 		exec = []string{"go", "run", "."}
@@ -100,15 +108,28 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	default:
 		// new service runtime
 		r = rs.NewRuntime()
-		// NOTE: we consider source in default mode
-		// to be the canonical Go module import path
-		// if source is empty, we bail as this can
-		// lead to a potential K8s API object creation DDOS
+		// if source is empty, we bail as this can lead to a potential
+		// K8s API object creation DDOS
 		if len(source) == 0 {
 			fmt.Println(RunUsage)
 			return
 		}
-		exec = []string{"go", "run", source}
+
+		// resolve source by URL scheme: go://, git+https://, oci:// or
+		// file://, defaulting to a Go import path when no scheme is given
+		workdir, resolved, err := rtsource.Resolve(source)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		exec = resolved
+
+		if workdir != "." {
+			if err := os.Chdir(workdir); err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
 	}
 
 	// start the local runtime
@@ -135,11 +156,18 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		}
 	}
 
+	// in local mode, tee output into the capture buffer `micro logs`
+	// reads from, in addition to the console
+	output := io.Writer(os.Stdout)
+	if local {
+		output = captureOutput(os.Stdout, name, version)
+	}
+
 	// runtime based on environment we run the service in
 	// TODO: how will this work with runtime service
 	opts := []runtime.CreateOption{
 		runtime.WithCommand(exec...),
-		runtime.WithOutput(os.Stdout),
+		runtime.WithOutput(output),
 		runtime.WithEnv(environment),
 	}
 
@@ -149,6 +177,45 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		return
 	}
 
+	// supervise the process and apply the restart policy, unless the
+	// user explicitly asked us not to
+	if restart := ctx.String("restart"); restart != string(supervisor.RestartNo) {
+		pid, err := strconv.Atoi(service.Metadata["pid"])
+		if err != nil {
+			fmt.Printf("Could not supervise %s: %v\n", service.Name, err)
+		} else {
+			sup := supervisor.New(r, service, pid, supervisor.Options{
+				Restart:     supervisor.Policy(restart),
+				MaxRestarts: ctx.Int("max-restarts"),
+				Command:     exec,
+				Env:         environment,
+				Output:      output,
+			})
+			go sup.Watch(time.Second)
+		}
+	}
+
+	// in local mode, watch the source tree and hot reload on change
+	var reloader *watcher.Reloader
+	if local {
+		if mode := watcher.Mode(ctx.String("reload")); mode != watcher.ReloadOff {
+			// source may have been relative to the directory micro was
+			// started in, and we've since os.Chdir'd into it above, so
+			// resolve the watch dir from the post-chdir cwd rather than
+			// reusing the (possibly now-stale) source path verbatim
+			dir, err := os.Getwd()
+			if err != nil {
+				fmt.Printf("Could not start watcher: %v\n", err)
+			} else if watchDir, err := watcher.New(dir, ctx.StringSlice("watch-ignore")); err != nil {
+				fmt.Printf("Could not start watcher: %v\n", err)
+			} else {
+				reloader = watcher.NewReloader(r, service, dir, mode)
+				go watchDir.Watch(reloader.Reload)
+				defer watchDir.Close()
+			}
+		}
+	}
+
 	// if in local mode register signal handlers
 	if local {
 		shutdown := make(chan os.Signal, 1)
@@ -157,8 +224,16 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		// wait for shutdown
 		<-shutdown
 
+		// a --reload=swap may have replaced service with a differently
+		// versioned instance since we started; tear down whichever one
+		// is actually running rather than the now-stale original
+		running := service
+		if reloader != nil {
+			running = reloader.Service()
+		}
+
 		// delete service from runtime
-		if err := r.Delete(service); err != nil {
+		if err := r.Delete(running); err != nil {
 			fmt.Println(err)
 			return
 		}
@@ -207,6 +282,20 @@ func killService(ctx *cli.Context, srvOpts ...micro.Option) {
 }
 
 func getService(ctx *cli.Context, srvOpts ...micro.Option) {
+	watch := ctx.Bool("watch")
+	if !watch {
+		printServices(ctx)
+		return
+	}
+
+	// redraw the table on every tick until interrupted
+	for range time.Tick(3 * time.Second) {
+		fmt.Print("\033[H\033[2J")
+		printServices(ctx)
+	}
+}
+
+func printServices(ctx *cli.Context) {
 	// get the args
 	name := ctx.String("name")
 	version := ctx.String("version")
@@ -0,0 +1,37 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// cacheDir returns the directory fetched sources for ref should be
+// cached under, creating it if necessary.
+func cacheDir(ref string) (string, error) {
+	root := os.Getenv("XDG_CACHE_HOME")
+	if len(root) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		root = filepath.Join(home, ".cache")
+	}
+
+	sum := sha256.Sum256([]byte(ref))
+	dir := filepath.Join(root, "micro", "sources", hex.EncodeToString(sum[:]))
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// cached reports whether dir already holds a non-empty fetch from a
+// previous run, so resolvers can skip re-fetching.
+func cached(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	return err == nil && len(entries) > 0
+}
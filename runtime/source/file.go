@@ -0,0 +1,102 @@
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	Register(&fileResolver{})
+}
+
+// fileResolver unpacks a local tarball, file://path/to/source.tar.gz,
+// into the source cache and runs it as Go source.
+type fileResolver struct{}
+
+func (r *fileResolver) Scheme() string { return "file" }
+
+func (r *fileResolver) Fetch(ref string) (string, []string, error) {
+	dir, err := cacheDir(ref)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !cached(dir) {
+		if err := untar(ref, dir); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return dir, []string{"go", "run", "."}, nil
+}
+
+func untar(src, dest string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path, err := safeJoin(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			out, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		default:
+			return fmt.Errorf("unsupported tar entry: %s", hdr.Name)
+		}
+	}
+}
+
+// safeJoin joins name onto dest and rejects the result if it would
+// land outside dest, e.g. via a "../" entry in a crafted tarball
+// (tar-slip, CWE-22).
+func safeJoin(dest, name string) (string, error) {
+	dest = filepath.Clean(dest)
+	path := filepath.Join(dest, name)
+
+	if path != dest && !strings.HasPrefix(path, dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("tar entry escapes destination: %s", name)
+	}
+
+	return path, nil
+}
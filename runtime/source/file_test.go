@@ -0,0 +1,71 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoinRejectsTraversal(t *testing.T) {
+	dest := "/cache/sources/abc123"
+
+	if _, err := safeJoin(dest, "../../etc/passwd"); err == nil {
+		t.Error("expected safeJoin to reject a path escaping dest")
+	}
+
+	if _, err := safeJoin(dest, "../abc123-sibling/evil"); err == nil {
+		t.Error("expected safeJoin to reject a path escaping dest via a sibling prefix")
+	}
+
+	path, err := safeJoin(dest, "pkg/main.go")
+	if err != nil {
+		t.Fatalf("safeJoin rejected a legitimate entry: %v", err)
+	}
+	if want := filepath.Join(dest, "pkg/main.go"); path != want {
+		t.Errorf("safeJoin(%q) = %q, want %q", "pkg/main.go", path, want)
+	}
+}
+
+func TestUntarRejectsPathTraversal(t *testing.T) {
+	root := t.TempDir()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	content := []byte("pwned")
+	if err := tw.WriteHeader(&tar.Header{
+		Name: "../../etc/passwd",
+		Mode: 0644,
+		Size: int64(len(content)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+
+	archive := filepath.Join(root, "evil.tar.gz")
+	if err := os.WriteFile(archive, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(root, "unpack")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := untar(archive, dest); err == nil {
+		t.Fatal("expected untar to reject a path-traversal tar entry")
+	}
+
+	// dest is root/unpack, so "../../etc/passwd" would land beside root
+	if _, err := os.Stat(filepath.Join(filepath.Dir(root), "etc", "passwd")); err == nil {
+		t.Fatal("tar-slip entry escaped the destination directory")
+	}
+}
@@ -0,0 +1,41 @@
+package source
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func init() {
+	Register(&gitResolver{})
+}
+
+// gitResolver fetches a git+https://host/repo@ref style reference by
+// shallow-cloning it into the source cache.
+type gitResolver struct{}
+
+func (r *gitResolver) Scheme() string { return "git+https" }
+
+func (r *gitResolver) Fetch(ref string) (string, []string, error) {
+	repo, rev := ref, ""
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		repo, rev = ref[:i], ref[i+1:]
+	}
+
+	dir, err := cacheDir(ref)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !cached(dir) {
+		clone := exec.Command("git", "clone", "--depth", "1", "https://"+repo, dir)
+		if len(rev) > 0 {
+			clone.Args = append(clone.Args, "--branch", rev)
+		}
+		if out, err := clone.CombinedOutput(); err != nil {
+			return "", nil, fmt.Errorf("git clone failed: %v: %s", err, out)
+		}
+	}
+
+	return dir, []string{"go", "run", "."}, nil
+}
@@ -0,0 +1,16 @@
+package source
+
+func init() {
+	Register(&goResolver{})
+}
+
+// goResolver treats ref as either a canonical Go module import path
+// or a local filesystem directory, matching the runtime's historic
+// behaviour before pluggable resolvers existed.
+type goResolver struct{}
+
+func (r *goResolver) Scheme() string { return "go" }
+
+func (r *goResolver) Fetch(ref string) (string, []string, error) {
+	return ".", []string{"go", "run", ref}, nil
+}
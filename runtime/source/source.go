@@ -0,0 +1,48 @@
+// Package source resolves a service's --source flag into a working
+// directory and exec command, pulling it from wherever it actually
+// lives (a Go import path, a git remote, an OCI image or a local
+// tarball).
+package source
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolver fetches a source reference and returns the working
+// directory it was materialized into along with the command vector
+// needed to run it.
+type Resolver interface {
+	// Scheme is the URL scheme this resolver handles, e.g. "git+https".
+	Scheme() string
+	// Fetch resolves ref into a workdir and exec command.
+	Fetch(ref string) (workdir string, cmd []string, err error)
+}
+
+var resolvers = make(map[string]Resolver)
+
+// Register adds a Resolver so Resolve can dispatch to it by scheme.
+// It's expected to be called from init() by each built-in resolver.
+func Register(r Resolver) {
+	resolvers[r.Scheme()] = r
+}
+
+// Resolve picks the Resolver matching ref's URL scheme and fetches it.
+// A ref with no scheme (a bare Go import path or filesystem dir) is
+// treated as "go://".
+func Resolve(ref string) (string, []string, error) {
+	scheme := "go"
+	rest := ref
+
+	if i := strings.Index(ref, "://"); i >= 0 {
+		scheme = ref[:i]
+		rest = ref[i+3:]
+	}
+
+	r, ok := resolvers[scheme]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported source scheme: %s", scheme)
+	}
+
+	return r.Fetch(rest)
+}
@@ -0,0 +1,259 @@
+// Package supervisor tracks the child processes started by the micro
+// runtime and keeps them alive according to a restart policy.
+package supervisor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/runtime"
+	ps "github.com/mitchellh/go-ps"
+)
+
+// Policy describes how a supervised process should be restarted
+// when it exits.
+type Policy string
+
+const (
+	// RestartNo never restarts the process once it exits.
+	RestartNo Policy = "no"
+	// RestartOnFailure restarts the process only on a non-zero exit code.
+	RestartOnFailure Policy = "on-failure"
+	// RestartAlways restarts the process regardless of exit code.
+	RestartAlways Policy = "always"
+)
+
+// Status values written to Service.Metadata["status"].
+const (
+	StatusRunning    = "running"
+	StatusExited     = "exited"
+	StatusCrashed    = "crashed"
+	StatusRestarting = "restarting"
+)
+
+// Options configure a Supervisor.
+type Options struct {
+	// Restart is the policy applied when a process exits.
+	Restart Policy
+	// MaxRestarts caps the number of restart attempts. Zero means
+	// unlimited.
+	MaxRestarts int
+	// Backoff is the delay strategy between restarts.
+	Backoff BackoffFunc
+
+	// Command and Env are used to relaunch the process on restart.
+	// The first run is started by r.Create before the Supervisor
+	// exists, so these only matter from the first restart onward.
+	Command []string
+	Env     []string
+	// Output, if set, is where a respawned process's stdout/stderr
+	// are written, e.g. the same capture writer the first run used.
+	Output io.Writer
+}
+
+// BackoffFunc returns the delay to wait before the nth restart attempt.
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay on each attempt starting at 1s,
+// capped at 30s.
+func ExponentialBackoff(attempt int) time.Duration {
+	d := time.Second * time.Duration(math.Pow(2, float64(attempt-1)))
+	if d > 30*time.Second {
+		d = 30 * time.Second
+	}
+	return d
+}
+
+// Supervisor watches a single service process and enforces its
+// restart policy, updating the service's status metadata as it does so.
+type Supervisor struct {
+	sync.Mutex
+
+	r       runtime.Runtime
+	service *runtime.Service
+	opts    Options
+
+	pid int
+	// proc is non-nil once the Supervisor has respawned the process
+	// itself rather than relying on r.Create to have done so. Only
+	// then can Wait() report a real exit status.
+	proc     *exec.Cmd
+	restarts int
+	exit     chan struct{}
+}
+
+// New creates a Supervisor for the given service and pid, using r to
+// re-create the service when a restart is required.
+func New(r runtime.Runtime, service *runtime.Service, pid int, opts Options) *Supervisor {
+	if opts.Backoff == nil {
+		opts.Backoff = ExponentialBackoff
+	}
+
+	return &Supervisor{
+		r:       r,
+		service: service,
+		opts:    opts,
+		pid:     pid,
+		exit:    make(chan struct{}),
+	}
+}
+
+// Watch waits for the process to exit, then applies the restart
+// policy using the exit status if one is available. It blocks until
+// the supervisor is stopped or the restart policy gives up.
+func (s *Supervisor) Watch(interval time.Duration) {
+	s.setStatus(StatusRunning)
+
+	for {
+		failed, known, stopped := s.waitForExit(interval)
+		if stopped {
+			return
+		}
+
+		if !s.shouldRestart(failed, known) {
+			if failed {
+				s.setStatus(StatusCrashed)
+			} else {
+				s.setStatus(StatusExited)
+			}
+			return
+		}
+
+		s.setStatus(StatusRestarting)
+		s.Lock()
+		s.restarts++
+		attempt := s.restarts
+		s.Unlock()
+
+		time.Sleep(s.opts.Backoff(attempt))
+
+		if err := s.respawn(); err != nil {
+			s.setStatus(StatusCrashed)
+			fmt.Printf("supervisor: restart failed: %v\n", err)
+			return
+		}
+
+		s.setStatus(StatusRunning)
+	}
+}
+
+// waitForExit blocks until the supervised process exits, or the
+// Supervisor is stopped, reporting whether the exit looked like a
+// failure. known is false when no real exit status is available at
+// all (the pid-polling path, used only before the first restart), in
+// which case failed conservatively reports true, since a supervised
+// service disappearing from the process table is itself abnormal.
+func (s *Supervisor) waitForExit(interval time.Duration) (failed, known, stopped bool) {
+	s.Lock()
+	proc := s.proc
+	s.Unlock()
+
+	// once we've respawned the process ourselves, Wait() on our own
+	// child gives us its real exit status
+	if proc != nil {
+		err := proc.Wait()
+		select {
+		case <-s.exit:
+			return false, true, true
+		default:
+		}
+		return err != nil, true, false
+	}
+
+	// the first run was started by r.Create, not by us, so there's no
+	// *exec.Cmd to Wait() on; fall back to polling the process table
+	// for its disappearance
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.exit:
+			return false, false, true
+		case <-ticker.C:
+			p, err := ps.FindProcess(s.pid)
+			if err != nil || p != nil {
+				continue
+			}
+			return true, false, false
+		}
+	}
+}
+
+// respawn relaunches the service's command directly, so the
+// Supervisor owns the child from here on and can Wait() on its real
+// exit status, and retires the runtime-created record for the
+// instance it's replacing.
+func (s *Supervisor) respawn() error {
+	if err := s.r.Delete(s.service); err != nil {
+		fmt.Printf("supervisor: delete failed: %v\n", err)
+	}
+
+	if len(s.opts.Command) == 0 {
+		return fmt.Errorf("no command to restart %s with", s.service.Name)
+	}
+
+	cmd := exec.Command(s.opts.Command[0], s.opts.Command[1:]...)
+	cmd.Env = s.opts.Env
+	if s.opts.Output != nil {
+		cmd.Stdout = s.opts.Output
+		cmd.Stderr = s.opts.Output
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	s.Lock()
+	s.proc = cmd
+	s.pid = cmd.Process.Pid
+	s.Unlock()
+
+	if s.service.Metadata == nil {
+		s.service.Metadata = make(map[string]string)
+	}
+	s.service.Metadata["pid"] = strconv.Itoa(cmd.Process.Pid)
+
+	return nil
+}
+
+// Stop ends the watch loop without touching the underlying process.
+func (s *Supervisor) Stop() {
+	close(s.exit)
+}
+
+// shouldRestart decides whether the restart policy calls for another
+// attempt. known reports whether failed reflects a real exit status;
+// RestartOnFailure only skips a restart once we positively know the
+// exit was clean.
+func (s *Supervisor) shouldRestart(failed, known bool) bool {
+	switch s.opts.Restart {
+	case RestartAlways:
+	case RestartOnFailure:
+		if known && !failed {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if s.opts.MaxRestarts > 0 && s.restarts >= s.opts.MaxRestarts {
+		return false
+	}
+	return true
+}
+
+func (s *Supervisor) setStatus(status string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.service.Metadata == nil {
+		s.service.Metadata = make(map[string]string)
+	}
+	s.service.Metadata["status"] = status
+}
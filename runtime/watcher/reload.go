@@ -0,0 +1,195 @@
+package watcher
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/runtime"
+	ps "github.com/mitchellh/go-ps"
+)
+
+// healthCheckInterval and healthCheckRetries bound how long swap()
+// waits for a newly created instance's process to show up before
+// giving up on it.
+const (
+	healthCheckInterval = 200 * time.Millisecond
+	healthCheckRetries  = 10
+)
+
+// Mode selects how a detected change is applied to the running
+// service.
+type Mode string
+
+const (
+	// ReloadOff disables hot reload entirely.
+	ReloadOff Mode = "off"
+	// ReloadRestart stops the running service and starts the rebuilt
+	// binary in its place.
+	ReloadRestart Mode = "restart"
+	// ReloadSwap starts the rebuilt binary alongside the running one,
+	// waits for it to come up, then stops the old one (blue/green).
+	ReloadSwap Mode = "swap"
+)
+
+// Reloader rebuilds a service's source on change and applies the
+// result to a running runtime.Service according to Mode.
+type Reloader struct {
+	r    runtime.Runtime
+	dir  string
+	mode Mode
+
+	// mu guards service and rev: Reload runs on the watcher's goroutine,
+	// while Service is read from runService's shutdown path on the main
+	// goroutine
+	mu      sync.Mutex
+	service *runtime.Service
+
+	// rev counts swaps, so each candidate gets a version distinct from
+	// the instance it's replacing until the cutover is confirmed
+	rev int
+}
+
+// NewReloader creates a Reloader for the given service, built from the
+// source tree rooted at dir.
+func NewReloader(r runtime.Runtime, service *runtime.Service, dir string, mode Mode) *Reloader {
+	return &Reloader{r: r, service: service, dir: dir, mode: mode}
+}
+
+// Service returns the runtime.Service currently being reloaded. Its
+// identity changes after a successful --reload=swap, so callers that
+// need to operate on the live instance (e.g. tearing it down on
+// shutdown) should call this rather than holding onto the service
+// they passed to NewReloader.
+func (re *Reloader) Service() *runtime.Service {
+	re.mu.Lock()
+	defer re.mu.Unlock()
+	return re.service
+}
+
+// Reload builds dir into a temp binary and, depending on Mode, either
+// restarts the service in place or swaps the new binary in once it's
+// healthy before stopping the old one.
+func (re *Reloader) Reload() {
+	if re.mode == ReloadOff {
+		return
+	}
+
+	bin, err := re.build()
+	if err != nil {
+		fmt.Printf("watcher: build failed: %v\n", err)
+		return
+	}
+	defer os.Remove(bin)
+
+	switch re.mode {
+	case ReloadSwap:
+		re.swap(bin)
+	default:
+		re.restart(bin)
+	}
+}
+
+func (re *Reloader) build() (string, error) {
+	out, err := ioutil.TempFile("", "micro-reload-*")
+	if err != nil {
+		return "", err
+	}
+	out.Close()
+
+	cmd := exec.Command("go", "build", "-o", out.Name(), ".")
+	cmd.Dir = re.dir
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("%v: %s", err, output)
+	}
+
+	return out.Name(), nil
+}
+
+func (re *Reloader) restart(bin string) {
+	service := re.Service()
+
+	if err := re.r.Delete(service); err != nil {
+		fmt.Printf("watcher: delete failed: %v\n", err)
+		return
+	}
+
+	if err := re.r.Create(service, runtime.WithCommand(bin)); err != nil {
+		fmt.Printf("watcher: restart failed: %v\n", err)
+	}
+}
+
+func (re *Reloader) swap(bin string) {
+	current := re.Service()
+
+	re.mu.Lock()
+	re.rev++
+	rev := re.rev
+	re.mu.Unlock()
+
+	// give the candidate its own version so it doesn't collide with, or
+	// overwrite, the running instance's runtime-tracked record before
+	// the cutover is confirmed
+	next := &runtime.Service{
+		Name:     current.Name,
+		Version:  swapVersion(current.Version, rev),
+		Source:   current.Source,
+		Metadata: make(map[string]string),
+	}
+
+	if err := re.r.Create(next, runtime.WithCommand(bin)); err != nil {
+		fmt.Printf("watcher: swap build-up failed: %v\n", err)
+		return
+	}
+
+	if !healthy(next) {
+		fmt.Println("watcher: new instance failed health check, keeping old one running")
+		re.r.Delete(next)
+		return
+	}
+
+	if err := re.r.Delete(current); err != nil {
+		fmt.Printf("watcher: failed to stop old instance: %v\n", err)
+	}
+
+	// surface the swapped-in instance so callers (e.g. shutdown) that
+	// hold onto the Reloader, not the original service, tear down the
+	// right process
+	re.mu.Lock()
+	re.service = next
+	re.mu.Unlock()
+}
+
+// swapVersion derives the candidate's version tag from the service it
+// is replacing, keeping the two distinct until cutover.
+func swapVersion(version string, rev int) string {
+	if len(version) == 0 {
+		return fmt.Sprintf("reload-%d", rev)
+	}
+	return fmt.Sprintf("%s-reload-%d", version, rev)
+}
+
+// healthy polls the process table for the candidate's pid, giving it
+// a short window to come up before the swap is abandoned.
+func healthy(service *runtime.Service) bool {
+	for i := 0; i < healthCheckRetries; i++ {
+		time.Sleep(healthCheckInterval)
+
+		pid, err := strconv.Atoi(service.Metadata["pid"])
+		if err != nil {
+			continue
+		}
+
+		if proc, err := ps.FindProcess(pid); err == nil && proc != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package watcher
+
+import "testing"
+
+func TestSwapVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		rev     int
+		want    string
+	}{
+		{"latest", 1, "latest-reload-1"},
+		{"latest", 2, "latest-reload-2"},
+		{"", 1, "reload-1"},
+	}
+
+	for _, c := range cases {
+		if got := swapVersion(c.version, c.rev); got != c.want {
+			t.Errorf("swapVersion(%q, %d) = %q, want %q", c.version, c.rev, got, c.want)
+		}
+	}
+
+	// successive swaps of the same service must never collide
+	if swapVersion("latest", 1) == swapVersion("latest", 2) {
+		t.Error("swapVersion must produce a distinct version per swap")
+	}
+}
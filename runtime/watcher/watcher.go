@@ -0,0 +1,117 @@
+// Package watcher watches a service's source tree for changes and
+// triggers a rebuild, powering `micro run --local`'s hot reload loop.
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounce window applied to bursts of filesystem events, e.g. a
+// `git checkout` or an editor's atomic save.
+const debounce = 300 * time.Millisecond
+
+// watchedExt are the file extensions (and bare filenames) that trigger
+// a rebuild when changed.
+var watchedExt = map[string]bool{
+	".go":    true,
+	"go.mod": true,
+	"go.sum": true,
+}
+
+// Watcher recursively watches a directory tree and calls a callback,
+// debounced, whenever a relevant file changes.
+type Watcher struct {
+	fs     *fsnotify.Watcher
+	dir    string
+	ignore []string
+}
+
+// New creates a Watcher rooted at dir. ignore is a list of glob
+// patterns, matched against paths relative to dir, that are excluded
+// from triggering a rebuild (e.g. "vendor/*", "*_test.go").
+func New(dir string, ignore []string) (*Watcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{fs: fs, dir: dir, ignore: ignore}
+
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return w.fs.Add(path)
+		}
+		return nil
+	}); err != nil {
+		fs.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Watch blocks, invoking onChange (debounced) each time fsnotify
+// reports any event (create, write, remove, rename or chmod) for a
+// relevant file under the watched tree. It returns when the watcher
+// is closed.
+func (w *Watcher) Watch(onChange func()) {
+	var timer *time.Timer
+
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if !w.relevant(event.Name) {
+				continue
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounce, onChange)
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// Close stops the underlying filesystem watches.
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+func (w *Watcher) relevant(path string) bool {
+	base := filepath.Base(path)
+
+	matched := watchedExt[filepath.Ext(path)] || watchedExt[base]
+	if !matched {
+		return false
+	}
+
+	rel := path
+	if r, err := filepath.Rel(w.dir, path); err == nil {
+		rel = r
+	}
+
+	for _, pattern := range w.ignore {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return false
+		}
+	}
+
+	return true
+}